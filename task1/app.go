@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+	"google.golang.org/grpc"
+
+	userv1 "github.com/KirillGreenev/test-tasks-GO/task1/pb/user/v1"
+)
+
+// App owns everything main used to wire by hand: the database connection,
+// migrations, the repo/cache/service/controller stack, and the HTTP and
+// gRPC servers built on top of it.
+type App struct{}
+
+func NewApp() *App {
+	return &App{}
+}
+
+func openDB(cfg *Config) (*sqlx.DB, error) {
+	return sqlx.Open(cfg.DBDriver, cfg.DBDSN)
+}
+
+// Run opens the database, applies pending migrations, wires the service,
+// and serves HTTP and gRPC until ctx is cancelled, then shuts both servers
+// down within cfg.ShutdownTimeout.
+func (a *App) Run(ctx context.Context, cfg *Config) error {
+	SetPepper(cfg.PasswordPepper)
+
+	db, err := openDB(cfg)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+	defer db.Close()
+
+	if err := NewMigrator(db).Up(); err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+
+	userService := NewUserServiceImpl(newCacheRepository(NewUserRepositoryImpl(db), cfg))
+	controller := NewControllerUser(userService)
+
+	r := chi.NewRouter()
+	r.Use(RecoverMiddleware)
+	r.Post("/user", controller.RegisterHandler)
+	r.Get("/user", controller.GetUsersHandler)
+	r.Post("/user/login", controller.LoginHandler)
+
+	httpServer := &http.Server{
+		Addr:         cfg.HTTPAddr,
+		Handler:      r,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+	}
+
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(ErrorTranslationInterceptor))
+	userv1.RegisterUserServiceServer(grpcServer, newGRPCUserServer(userService))
+
+	grpcListener, err := net.Listen("tcp", cfg.GRPCAddr)
+	if err != nil {
+		return fmt.Errorf("Run: %w", err)
+	}
+
+	errCh := make(chan error, 2)
+
+	go func() {
+		log.Printf("Starting HTTP server on %s...", cfg.HTTPAddr)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- fmt.Errorf("http server: %w", err)
+		}
+	}()
+
+	go func() {
+		log.Printf("Starting gRPC server on %s...", cfg.GRPCAddr)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			errCh <- fmt.Errorf("grpc server: %w", err)
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+	case err := <-errCh:
+		log.Println("Run: server error, shutting down: ", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
+	httpErr := httpServer.Shutdown(shutdownCtx)
+
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		grpcServer.Stop()
+	}
+
+	if httpErr != nil {
+		return fmt.Errorf("Run: %w", httpErr)
+	}
+
+	log.Println("Server stopped gracefully")
+	return nil
+}
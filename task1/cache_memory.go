@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// defaultCacheCapacity bounds how many users the in-memory CacheProxy keeps
+// around, so a long-running process with many registrations can't grow the
+// cache without limit.
+const defaultCacheCapacity = 1000
+
+// CacheProxy is an in-process, in-memory Proxy-pattern implementation of
+// UserRepository. It caches the result of GetAll in a bounded LRU and
+// invalidates that cache on every Create, rather than tracking a row count
+// that can drift if a write fails partway through.
+type CacheProxy struct {
+	repo    UserRepository
+	mu      sync.RWMutex
+	cache   *lruCache
+	allSeen bool
+}
+
+func NewCacheProxy(repo UserRepository) *CacheProxy {
+	return &CacheProxy{
+		repo:  repo,
+		cache: newLRUCache(defaultCacheCapacity),
+	}
+}
+
+func (cp *CacheProxy) Create(ctx context.Context, user *User) error {
+	if err := cp.repo.Create(ctx, user); err != nil {
+		return err
+	}
+
+	cp.mu.Lock()
+	cp.allSeen = false
+	cp.mu.Unlock()
+
+	return nil
+}
+
+func (cp *CacheProxy) GetAll(ctx context.Context) ([]User, error) {
+	cp.mu.RLock()
+	if cp.allSeen {
+		users := cp.cache.All()
+		cp.mu.RUnlock()
+		return users, nil
+	}
+	cp.mu.RUnlock()
+
+	users, err := cp.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cp.mu.Lock()
+	cp.cache.Reset()
+	for _, user := range users {
+		cp.cache.Put(user.ID, user)
+	}
+	// allSeen only holds while every row fits in the bounded cache; once the
+	// table outgrows the capacity we always fall through to the repo.
+	cp.allSeen = len(users) <= defaultCacheCapacity
+	cp.mu.Unlock()
+
+	return users, nil
+}
+
+func (cp *CacheProxy) FindByEmail(ctx context.Context, email string) (*User, error) {
+	return cp.repo.FindByEmail(ctx, email)
+}
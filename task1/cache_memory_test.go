@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+)
+
+// fakeUserRepository is an in-memory UserRepository stand-in used to drive
+// CacheProxy without touching sqlite, recording how many times GetAll was
+// called so tests can assert on cache hits/misses. findByEmailErr, when
+// set, lets a test simulate a repo failure other than "no such user".
+type fakeUserRepository struct {
+	users          []User
+	getAllCalls    int
+	findByEmailErr error
+}
+
+func (f *fakeUserRepository) Create(_ context.Context, user *User) error {
+	user.ID = len(f.users) + 1
+	f.users = append(f.users, *user)
+	return nil
+}
+
+func (f *fakeUserRepository) GetAll(_ context.Context) ([]User, error) {
+	f.getAllCalls++
+	return f.users, nil
+}
+
+func (f *fakeUserRepository) FindByEmail(_ context.Context, email string) (*User, error) {
+	if f.findByEmailErr != nil {
+		return nil, f.findByEmailErr
+	}
+
+	for i := range f.users {
+		if f.users[i].Email == email {
+			return &f.users[i], nil
+		}
+	}
+	return nil, sql.ErrNoRows
+}
+
+func TestCacheProxyServesGetAllFromCacheUntilCreate(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeUserRepository{}
+	cp := NewCacheProxy(repo)
+
+	if _, err := cp.GetAll(ctx); err != nil {
+		t.Fatalf("GetAll (1st): %v", err)
+	}
+	if _, err := cp.GetAll(ctx); err != nil {
+		t.Fatalf("GetAll (2nd): %v", err)
+	}
+	if repo.getAllCalls != 1 {
+		t.Fatalf("expected the 2nd GetAll to be served from cache, repo.GetAll called %d times", repo.getAllCalls)
+	}
+
+	if err := cp.Create(ctx, &User{Email: "new@example.com", Age: 20}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if _, err := cp.GetAll(ctx); err != nil {
+		t.Fatalf("GetAll (after Create): %v", err)
+	}
+	if repo.getAllCalls != 2 {
+		t.Fatalf("expected Create to invalidate the cache, repo.GetAll called %d times, want 2", repo.getAllCalls)
+	}
+}
+
+func TestCacheProxyBypassesCacheBeyondCapacity(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeUserRepository{}
+	cp := NewCacheProxy(repo)
+
+	repo.users = make([]User, defaultCacheCapacity+1)
+	for i := range repo.users {
+		repo.users[i] = User{ID: i + 1}
+	}
+
+	if _, err := cp.GetAll(ctx); err != nil {
+		t.Fatalf("GetAll (1st): %v", err)
+	}
+	if _, err := cp.GetAll(ctx); err != nil {
+		t.Fatalf("GetAll (2nd): %v", err)
+	}
+
+	if repo.getAllCalls != 2 {
+		t.Fatalf("expected every GetAll to hit the repo once the result overflows the cache, repo.GetAll called %d times, want 2", repo.getAllCalls)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/valkey-io/valkey-go"
+)
+
+// newCacheRepository wires the configured cache backend in front of repo.
+// cfg.CacheBackend "valkey" wraps repo with a ValkeyCacheProxy pointed at
+// cfg.ValkeyAddr; any other value falls back to the in-process CacheProxy.
+func newCacheRepository(repo UserRepository, cfg *Config) UserRepository {
+	if cfg.CacheBackend != "valkey" {
+		return NewCacheProxy(repo)
+	}
+
+	client, err := valkey.NewClient(valkey.ClientOption{InitAddress: []string{cfg.ValkeyAddr}})
+	if err != nil {
+		log.Println("newCacheRepository: failed to connect to valkey, falling back to in-memory cache: ", err)
+		return NewCacheProxy(repo)
+	}
+
+	return NewValkeyCacheProxy(repo, client)
+}
+
+// defaultCacheTTL is how long a cached user (and the "all users" set) stays
+// valid in Valkey before it must be reloaded from the underlying repo.
+const defaultCacheTTL = 5 * time.Minute
+
+const allUsersSetKey = "users:all"
+
+func userCacheKey(id int) string {
+	return fmt.Sprintf("user:%d", id)
+}
+
+// ValkeyCacheProxy is a Proxy-pattern UserRepository backed by an external
+// Valkey/Redis instance, for deployments that run more than one instance of
+// the service and need a shared cache instead of the in-process CacheProxy.
+// Any cache miss or Valkey outage falls back to the wrapped repo so a
+// degraded cache never fails the request.
+type ValkeyCacheProxy struct {
+	repo   UserRepository
+	client valkey.Client
+	ttl    time.Duration
+}
+
+func NewValkeyCacheProxy(repo UserRepository, client valkey.Client) *ValkeyCacheProxy {
+	return &ValkeyCacheProxy{repo: repo, client: client, ttl: defaultCacheTTL}
+}
+
+func (cp *ValkeyCacheProxy) Create(ctx context.Context, user *User) error {
+	if err := cp.repo.Create(ctx, user); err != nil {
+		return err
+	}
+
+	// The set of all users changed; rather than try to patch it, drop it so
+	// the next GetAll rebuilds it from the repo.
+	cmd := cp.client.B().Del().Key(allUsersSetKey).Build()
+	if err := cp.client.Do(ctx, cmd).Error(); err != nil {
+		log.Println("ValkeyCacheProxy.Create: failed to invalidate users:all, continuing: ", err)
+	}
+
+	return nil
+}
+
+func (cp *ValkeyCacheProxy) GetAll(ctx context.Context) ([]User, error) {
+	ids, err := cp.client.Do(ctx, cp.client.B().Zrange().Key(allUsersSetKey).Min("0").Max("-1").Build()).AsStrSlice()
+	if err == nil && len(ids) > 0 {
+		if users, ok := cp.loadUsers(ctx, ids); ok {
+			return users, nil
+		}
+	}
+
+	users, err := cp.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cp.populateAll(ctx, users)
+
+	return users, nil
+}
+
+func (cp *ValkeyCacheProxy) FindByEmail(ctx context.Context, email string) (*User, error) {
+	return cp.repo.FindByEmail(ctx, email)
+}
+
+// loadUsers fetches every id from Valkey and reports ok=false (a partial or
+// failed cache) so the caller falls back to the repo instead of returning an
+// incomplete list.
+func (cp *ValkeyCacheProxy) loadUsers(ctx context.Context, ids []string) ([]User, bool) {
+	users := make([]User, 0, len(ids))
+	for _, id := range ids {
+		raw, err := cp.client.Do(ctx, cp.client.B().Get().Key("user:"+id).Build()).ToString()
+		if err != nil {
+			log.Println("ValkeyCacheProxy.GetAll: cache miss/outage, falling back to repo: ", err)
+			return nil, false
+		}
+
+		var user User
+		if err := json.Unmarshal([]byte(raw), &user); err != nil {
+			log.Println("ValkeyCacheProxy.GetAll: corrupt cache entry, falling back to repo: ", err)
+			return nil, false
+		}
+		users = append(users, user)
+	}
+	return users, true
+}
+
+func (cp *ValkeyCacheProxy) populateAll(ctx context.Context, users []User) {
+	for _, user := range users {
+		raw, err := json.Marshal(user)
+		if err != nil {
+			continue
+		}
+
+		setCmd := cp.client.B().Set().Key(userCacheKey(user.ID)).Value(string(raw)).Ex(cp.ttl).Build()
+		if err := cp.client.Do(ctx, setCmd).Error(); err != nil {
+			log.Println("ValkeyCacheProxy.populateAll: failed to cache user, continuing: ", err)
+			continue
+		}
+
+		zaddCmd := cp.client.B().Zadd().Key(allUsersSetKey).ScoreMember().ScoreMember(float64(user.ID), strconv.Itoa(user.ID)).Build()
+		if err := cp.client.Do(ctx, zaddCmd).Error(); err != nil {
+			log.Println("ValkeyCacheProxy.populateAll: failed to index user, continuing: ", err)
+		}
+	}
+
+	if err := cp.client.Do(ctx, cp.client.B().Expire().Key(allUsersSetKey).Seconds(int64(cp.ttl.Seconds())).Build()).Error(); err != nil {
+		log.Println("ValkeyCacheProxy.populateAll: failed to set TTL on users:all, continuing: ", err)
+	}
+}
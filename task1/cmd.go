@@ -0,0 +1,78 @@
+// Написать мини сервис с разделением слоев в одном main.go файле. Сервис должен уметь:
+// 1. Подключаться к базе данных
+// 2. Использовать кэш c применением Proxy паттерна
+// 3. Принимать http запросы REST like API
+// 4. Регистрировать пользователя в базе данных
+// 5. Выводить список всех пользователей
+// 6. У пользователя следующие данные email, password, name, age
+// 7. Запретить регистрацию пользователей с одинаковым email и возрастом меньше 18 лет
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// main is a thin entrypoint: it loads Config and either dispatches to the
+// migrate subcommand or hands off to App.Run, which owns everything else.
+func main() {
+	cfg, err := LoadConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrateCommand(cfg, os.Args[2:])
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := NewApp().Run(ctx, cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// runMigrateCommand implements `go run . migrate up|down|status` by opening
+// the database without applying pending migrations automatically, then
+// dispatching to the Migrator.
+func runMigrateCommand(cfg *Config, args []string) {
+	if len(args) == 0 {
+		log.Fatal("usage: migrate up|down|status")
+	}
+
+	db, err := openDB(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	migrator := NewMigrator(db)
+
+	switch args[0] {
+	case "up":
+		if err := migrator.Up(); err != nil {
+			log.Fatal(err)
+		}
+		log.Println("migrations applied")
+	case "down":
+		if err := migrator.Down(); err != nil {
+			log.Fatal(err)
+		}
+		log.Println("last migration rolled back")
+	case "status":
+		status, err := migrator.Status()
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Print(status)
+	default:
+		log.Fatalf("unknown migrate subcommand %q", args[0])
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// Config holds every environment-specific setting the service needs. It is
+// loaded once at startup and then passed down to the pieces App wires
+// together, so nothing below main() reaches into the environment directly.
+type Config struct {
+	HTTPAddr string
+	GRPCAddr string
+	// DBDriver currently only accepts "sqlite3" (see validate). Adding a
+	// second driver, e.g. postgres, needs more than a config change: the
+	// `?` placeholders in UserRepositoryImpl/Migrator and the sqlite-specific
+	// migrations/*.sql would both need to become driver-aware first.
+	DBDriver        string
+	DBDSN           string
+	CacheBackend    string
+	ValkeyAddr      string
+	PasswordPepper  string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	ShutdownTimeout time.Duration
+}
+
+// LoadConfig reads a .env file if present (missing is fine, any other read
+// error is not), then builds a Config from the environment, applying
+// defaults and validating the result.
+func LoadConfig() (*Config, error) {
+	if err := godotenv.Load(); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("LoadConfig: reading .env: %w", err)
+	}
+
+	cfg := &Config{
+		HTTPAddr:        envOrDefault("HTTP_ADDR", ":8080"),
+		GRPCAddr:        envOrDefault("GRPC_ADDR", ":9090"),
+		DBDriver:        envOrDefault("DB_DRIVER", "sqlite3"),
+		DBDSN:           envOrDefault("DB_DSN", "./users.db"),
+		CacheBackend:    strings.ToLower(envOrDefault("CACHE_BACKEND", "memory")),
+		ValkeyAddr:      envOrDefault("VALKEY_ADDR", "localhost:6379"),
+		PasswordPepper:  os.Getenv("PASSWORD_PEPPER"),
+		ReadTimeout:     10 * time.Second,
+		WriteTimeout:    10 * time.Second,
+		ShutdownTimeout: 5 * time.Second,
+	}
+
+	if v := os.Getenv("READ_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("LoadConfig: invalid READ_TIMEOUT %q: %w", v, err)
+		}
+		cfg.ReadTimeout = d
+	}
+
+	if v := os.Getenv("WRITE_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("LoadConfig: invalid WRITE_TIMEOUT %q: %w", v, err)
+		}
+		cfg.WriteTimeout = d
+	}
+
+	if v := os.Getenv("SHUTDOWN_TIMEOUT"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("LoadConfig: invalid SHUTDOWN_TIMEOUT %q: %w", v, err)
+		}
+		cfg.ShutdownTimeout = d
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// validate fails fast with every problem at once, rather than one
+// Fatal-per-restart, so a misconfigured environment can be fixed in one pass.
+func (c *Config) validate() error {
+	var problems []string
+
+	if c.HTTPAddr == "" {
+		problems = append(problems, "HTTP_ADDR must not be empty")
+	}
+	if c.GRPCAddr == "" {
+		problems = append(problems, "GRPC_ADDR must not be empty")
+	}
+	if c.DBDriver != "sqlite3" {
+		// Only the sqlite3 driver is blank-imported today (see app.go). A
+		// postgres (or other) driver must be wired in there too before
+		// DB_DRIVER can safely select it.
+		problems = append(problems, fmt.Sprintf("DB_DRIVER %q is not supported (want sqlite3)", c.DBDriver))
+	}
+	if c.DBDSN == "" {
+		problems = append(problems, "DB_DSN must not be empty")
+	}
+	if c.CacheBackend != "memory" && c.CacheBackend != "valkey" {
+		problems = append(problems, fmt.Sprintf("CACHE_BACKEND %q is not supported (want memory or valkey)", c.CacheBackend))
+	}
+	if c.CacheBackend == "valkey" && c.ValkeyAddr == "" {
+		problems = append(problems, "VALKEY_ADDR must not be empty when CACHE_BACKEND=valkey")
+	}
+	if c.PasswordPepper == "" {
+		problems = append(problems, "PASSWORD_PEPPER must not be empty")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+// Package errs provides a small typed error taxonomy that controllers and
+// middleware can map onto transport-specific status codes (HTTP, gRPC, ...)
+// without the business logic layer needing to know about either transport.
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// Code identifies the category of an error, independent of transport.
+type Code string
+
+const (
+	ErrValidationFailed Code = "validation_failed"
+	ErrAlreadyExists    Code = "already_exists"
+	ErrNotFound         Code = "not_found"
+	ErrDeadlineExceeded Code = "deadline_exceeded"
+	ErrUnauthenticated  Code = "unauthenticated"
+	ErrInternal         Code = "internal"
+)
+
+// Error is the typed error carried through the service layer. It keeps the
+// original cause for logging while exposing a stable Code and a
+// human-readable Message safe to return to a client.
+type Error struct {
+	Code    Code
+	Message string
+	Cause   error
+	frame   runtime.Frame
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Code, e.Message, e.Cause)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Frame returns the caller that produced this error, for logging.
+func (e *Error) Frame() runtime.Frame {
+	return e.frame
+}
+
+func callerFrame() runtime.Frame {
+	pc, _, _, ok := runtime.Caller(2)
+	if !ok {
+		return runtime.Frame{}
+	}
+	frames := runtime.CallersFrames([]uintptr{pc})
+	frame, _ := frames.Next()
+	return frame
+}
+
+// New creates a typed Error with no wrapped cause.
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message, frame: callerFrame()}
+}
+
+// Wrap creates a typed Error that wraps an existing cause.
+func Wrap(err error, code Code, message string) *Error {
+	return &Error{Code: code, Message: message, Cause: err, frame: callerFrame()}
+}
+
+// Is reports whether err is an *Error with the given code.
+func Is(err error, code Code) bool {
+	var e *Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.Code == code
+}
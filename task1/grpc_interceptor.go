@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/KirillGreenev/test-tasks-GO/task1/errs"
+)
+
+// grpcCode mirrors errorStatus for the gRPC transport: the same *errs.Error
+// codes the HTTP middleware maps to status codes are mapped here to the
+// matching gRPC status code.
+var grpcCode = map[errs.Code]codes.Code{
+	errs.ErrValidationFailed: codes.InvalidArgument,
+	errs.ErrAlreadyExists:    codes.AlreadyExists,
+	errs.ErrNotFound:         codes.NotFound,
+	errs.ErrUnauthenticated:  codes.Unauthenticated,
+	errs.ErrDeadlineExceeded: codes.DeadlineExceeded,
+	errs.ErrInternal:         codes.Internal,
+}
+
+// ErrorTranslationInterceptor recovers from panics and converts any
+// *errs.Error returned by a handler into a grpc/status error carrying the
+// matching code, so callers get the same taxonomy over gRPC as over HTTP.
+func ErrorTranslationInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Println("recovered from panic in grpc handler: ", rec)
+			err = status.Error(codes.Internal, "internal server error")
+		}
+	}()
+
+	resp, err = handler(ctx, req)
+	if err == nil {
+		return resp, nil
+	}
+
+	var typed *errs.Error
+	if !errors.As(err, &typed) {
+		log.Println("grpc handler error: ", err)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	code, ok := grpcCode[typed.Code]
+	if !ok {
+		code = codes.Internal
+	}
+
+	return nil, status.Error(code, typed.Message)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+
+	userv1 "github.com/KirillGreenev/test-tasks-GO/task1/pb/user/v1"
+)
+
+// grpcUserServer adapts the UserService business logic to the generated
+// gRPC contract, so Register/List don't duplicate anything RegisterHandler
+// and GetUsersHandler already do — it's a thin transport-level translation.
+type grpcUserServer struct {
+	userv1.UnimplementedUserServiceServer
+	userService UserService
+}
+
+func newGRPCUserServer(userService UserService) *grpcUserServer {
+	return &grpcUserServer{userService: userService}
+}
+
+func (s *grpcUserServer) Register(ctx context.Context, req *userv1.RegisterRequest) (*userv1.RegisterResponse, error) {
+	user := &User{
+		Email:    req.Email,
+		Password: req.Password,
+		Name:     req.Name,
+		Age:      int(req.Age),
+	}
+
+	if err := s.userService.Create(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return &userv1.RegisterResponse{Id: int32(user.ID)}, nil
+}
+
+func (s *grpcUserServer) List(ctx context.Context, _ *userv1.ListRequest) (*userv1.ListResponse, error) {
+	users, err := s.userService.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &userv1.ListResponse{Users: make([]*userv1.User, 0, len(users))}
+	for _, user := range users {
+		resp.Users = append(resp.Users, &userv1.User{
+			Id:    int32(user.ID),
+			Email: user.Email,
+			Name:  user.Name,
+			Age:   int32(user.Age),
+		})
+	}
+
+	return resp, nil
+}
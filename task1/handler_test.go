@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestController wires a ControllerUser against a fresh migrated
+// in-memory sqlite DB, as App.Run does but without the cache layer in front
+// of the repository, so handler tests exercise the real repository/service
+// stack instead of a fake.
+func newTestController(t *testing.T) *ControllerUser {
+	t.Helper()
+
+	SetPepper("test-pepper")
+
+	db := openTestDB(t)
+	if err := NewMigrator(db).Up(); err != nil {
+		t.Fatalf("Migrator.Up: %v", err)
+	}
+
+	userService := NewUserServiceImpl(NewUserRepositoryImpl(db))
+	return NewControllerUser(userService)
+}
+
+func TestRegisterHandlerStoresThePostedPassword(t *testing.T) {
+	c := newTestController(t)
+
+	body := `{"email":"a@example.com","password":"hunter2","name":"A","age":30}`
+	req := httptest.NewRequest(http.MethodPost, "/user", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+
+	c.RegisterHandler(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("RegisterHandler: got status %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	loginBody := `{"email":"a@example.com","password":"hunter2"}`
+	loginReq := httptest.NewRequest(http.MethodPost, "/user/login", bytes.NewBufferString(loginBody))
+	loginRec := httptest.NewRecorder()
+
+	c.LoginHandler(loginRec, loginReq)
+
+	if loginRec.Code != http.StatusOK {
+		t.Fatalf("LoginHandler with the correct password: got status %d, body %q", loginRec.Code, loginRec.Body.String())
+	}
+
+	var user User
+	if err := json.Unmarshal(loginRec.Body.Bytes(), &user); err != nil {
+		t.Fatalf("decoding LoginHandler response: %v", err)
+	}
+	if user.Email != "a@example.com" {
+		t.Fatalf("expected the logged-in user's email, got %+v", user)
+	}
+}
+
+func TestLoginHandlerRejectsWrongAndEmptyPassword(t *testing.T) {
+	c := newTestController(t)
+
+	body := `{"email":"b@example.com","password":"correcthorse","name":"B","age":25}`
+	req := httptest.NewRequest(http.MethodPost, "/user", bytes.NewBufferString(body))
+	rec := httptest.NewRecorder()
+	c.RegisterHandler(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("RegisterHandler: got status %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	for _, password := range []string{"wrongpassword", ""} {
+		loginBody, err := json.Marshal(LoginRequest{Email: "b@example.com", Password: password})
+		if err != nil {
+			t.Fatalf("marshaling login body: %v", err)
+		}
+
+		loginReq := httptest.NewRequest(http.MethodPost, "/user/login", bytes.NewReader(loginBody))
+		loginRec := httptest.NewRecorder()
+		c.LoginHandler(loginRec, loginReq)
+
+		if loginRec.Code != http.StatusUnauthorized {
+			t.Fatalf("LoginHandler with password %q: got status %d, want %d, body %q", password, loginRec.Code, http.StatusUnauthorized, loginRec.Body.String())
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/KirillGreenev/test-tasks-GO/task1/errs"
+)
+
+func TestLoginMapsRepoFailureToInternalNotUnauthenticated(t *testing.T) {
+	SetPepper("test-pepper")
+
+	repo := &fakeUserRepository{findByEmailErr: errors.New("connection refused")}
+	svc := NewUserServiceImpl(repo)
+
+	_, err := svc.Login(context.Background(), "a@example.com", "whatever")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if errs.Is(err, errs.ErrUnauthenticated) {
+		t.Fatal("a non-not-found repo error must not be reported as ErrUnauthenticated")
+	}
+	if !errs.Is(err, errs.ErrInternal) {
+		t.Fatalf("expected ErrInternal, got: %v", err)
+	}
+}
+
+func TestLoginMapsMissingUserToUnauthenticated(t *testing.T) {
+	SetPepper("test-pepper")
+
+	repo := &fakeUserRepository{}
+	svc := NewUserServiceImpl(repo)
+
+	_, err := svc.Login(context.Background(), "nobody@example.com", "whatever")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !errs.Is(err, errs.ErrUnauthenticated) {
+		t.Fatalf("expected ErrUnauthenticated for a missing user, got: %v", err)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import "container/list"
+
+// lruCache is a fixed-capacity, least-recently-used cache of Users keyed by
+// ID. It is not safe for concurrent use on its own; callers are expected to
+// guard it with their own lock (see CacheProxy).
+type lruCache struct {
+	capacity int
+	order    *list.List
+	entries  map[int]*list.Element
+}
+
+type lruEntry struct {
+	key   int
+	value User
+}
+
+func newLRUCache(capacity int) *lruCache {
+	return &lruCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[int]*list.Element, capacity),
+	}
+}
+
+func (c *lruCache) Len() int {
+	return c.order.Len()
+}
+
+func (c *lruCache) Get(key int) (User, bool) {
+	elem, ok := c.entries[key]
+	if !ok {
+		return User{}, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry).value, true
+}
+
+func (c *lruCache) Put(key int, value User) {
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruEntry).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&lruEntry{key: key, value: value})
+	c.entries[key] = elem
+
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*lruEntry).key)
+		}
+	}
+}
+
+func (c *lruCache) All() []User {
+	users := make([]User, 0, c.order.Len())
+	for elem := c.order.Back(); elem != nil; elem = elem.Prev() {
+		users = append(users, elem.Value.(*lruEntry).value)
+	}
+	return users
+}
+
+func (c *lruCache) Reset() {
+	c.order.Init()
+	c.entries = make(map[int]*list.Element, c.capacity)
+}
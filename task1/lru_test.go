@@ -0,0 +1,61 @@
+package main
+
+import "testing"
+
+func TestLRUCacheEvictsOldest(t *testing.T) {
+	c := newLRUCache(2)
+
+	c.Put(1, User{ID: 1, Name: "one"})
+	c.Put(2, User{ID: 2, Name: "two"})
+	c.Put(3, User{ID: 3, Name: "three"})
+
+	if c.Len() != 2 {
+		t.Fatalf("expected capacity to cap length at 2, got %d", c.Len())
+	}
+
+	if _, ok := c.Get(1); ok {
+		t.Fatal("expected the oldest entry (key 1) to have been evicted")
+	}
+	if _, ok := c.Get(2); !ok {
+		t.Fatal("expected key 2 to still be cached")
+	}
+	if _, ok := c.Get(3); !ok {
+		t.Fatal("expected key 3 to still be cached")
+	}
+}
+
+func TestLRUCacheGetRefreshesRecency(t *testing.T) {
+	c := newLRUCache(2)
+
+	c.Put(1, User{ID: 1})
+	c.Put(2, User{ID: 2})
+
+	// Touch key 1 so it's no longer the least-recently-used entry.
+	if _, ok := c.Get(1); !ok {
+		t.Fatal("expected key 1 to be present")
+	}
+
+	c.Put(3, User{ID: 3})
+
+	if _, ok := c.Get(2); ok {
+		t.Fatal("expected key 2 to have been evicted as the new least-recently-used entry")
+	}
+	if _, ok := c.Get(1); !ok {
+		t.Fatal("expected key 1 to still be cached after being refreshed")
+	}
+}
+
+func TestLRUCacheReset(t *testing.T) {
+	c := newLRUCache(2)
+	c.Put(1, User{ID: 1})
+	c.Put(2, User{ID: 2})
+
+	c.Reset()
+
+	if c.Len() != 0 {
+		t.Fatalf("expected Reset to clear the cache, got len=%d", c.Len())
+	}
+	if _, ok := c.Get(1); ok {
+		t.Fatal("expected key 1 to be gone after Reset")
+	}
+}
@@ -1,43 +1,52 @@
-// Написать мини сервис с разделением слоев в одном main.go файле. Сервис должен уметь:
-// 1. Подключаться к базе данных
-// 2. Использовать кэш c применением Proxy паттерна
-// 3. Принимать http запросы REST like API
-// 4. Регистрировать пользователя в базе данных
-// 5. Выводить список всех пользователей
-// 6. У пользователя следующие данные email, password, name, age
-// 7. Запретить регистрацию пользователей с одинаковым email и возрастом меньше 18 лет
-
 package main
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
-	"fmt"
+	"errors"
 	"log"
 	"net/http"
-	"os"
-	"os/signal"
-	"syscall"
+	"strings"
 	"time"
 
-	"github.com/go-chi/chi"
 	"github.com/jmoiron/sqlx"
-	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/KirillGreenev/test-tasks-GO/task1/errs"
 )
 
 // models
 type User struct {
 	ID       int    `json:"id" db:"id"`
 	Email    string `json:"email" db:"email"`
-	Password string `json:"password" db:"password"`
+	Password string `json:"-" db:"password"`
+	Salt     string `json:"-" db:"salt"`
 	Name     string `json:"name" db:"name"`
 	Age      int    `json:"age" db:"age"`
 }
 
+// LoginRequest is the body accepted by POST /user/login.
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// RegisterRequest is the body accepted by POST /user. It exists separately
+// from User because User.Password is tagged json:"-" so it's never echoed
+// back in a response; decoding straight into a User would silently drop the
+// incoming password too.
+type RegisterRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Name     string `json:"name"`
+	Age      int    `json:"age"`
+}
+
 // repository
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
 	GetAll(ctx context.Context) ([]User, error)
+	FindByEmail(ctx context.Context, email string) (*User, error)
 }
 
 type UserRepositoryImpl struct {
@@ -48,31 +57,23 @@ func NewUserRepositoryImpl(db *sqlx.DB) *UserRepositoryImpl {
 	return &UserRepositoryImpl{db: db}
 }
 
-func initDB() (*sqlx.DB, error) {
-	db, err := sqlx.Open("sqlite3", "./users.db")
+func (repo *UserRepositoryImpl) Create(ctx context.Context, user *User) error {
+	result, err := repo.db.ExecContext(ctx, "INSERT INTO users (email, password, salt, name, age) VALUES (?, ?, ?, ?, ?)",
+		user.Email, user.Password, user.Salt, user.Name, user.Age)
 	if err != nil {
-		return nil, err
+		if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+			return errs.Wrap(err, errs.ErrAlreadyExists, "a user with this email already exists")
+		}
+		return errs.Wrap(err, errs.ErrInternal, "failed to create user")
 	}
 
-	createTableSQL := `CREATE TABLE IF NOT EXISTS users (
-                                     id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
-                                     email VARCHAR(100) NOT NULL UNIQUE,
-                                     name VARCHAR(100) NOT NULL,
-                                     age INTEGER,
-                                     password VARCHAR(100) NOT NULL
-);`
-
-	if _, err := db.Exec(createTableSQL); err != nil {
-		return nil, err
+	id, err := result.LastInsertId()
+	if err != nil {
+		return errs.Wrap(err, errs.ErrInternal, "failed to read inserted user id")
 	}
+	user.ID = int(id)
 
-	return db, nil
-}
-
-func (repo *UserRepositoryImpl) Create(ctx context.Context, user *User) error {
-	_, err := repo.db.ExecContext(ctx, "INSERT INTO users (email, password, name, age) VALUES (?, ?, ?, ?)",
-		user.Email, user.Password, user.Name, user.Age)
-	return err
+	return nil
 }
 
 func (repo *UserRepositoryImpl) GetAll(ctx context.Context) ([]User, error) {
@@ -81,51 +82,20 @@ func (repo *UserRepositoryImpl) GetAll(ctx context.Context) ([]User, error) {
 	return users, err
 }
 
-type CacheProxy struct {
-	repo        UserRepository
-	cache       map[int]User
-	countUserDb int
-}
-
-func NewCacheProxy(repo UserRepository) *CacheProxy {
-	return &CacheProxy{
-		repo:        repo,
-		cache:       make(map[int]User),
-		countUserDb: -100,
-	}
-}
-
-func (cp *CacheProxy) Create(ctx context.Context, user *User) error {
-	cp.countUserDb++
-	return cp.repo.Create(ctx, user)
-
-}
-func (cp *CacheProxy) GetAll(ctx context.Context) ([]User, error) {
-	if len(cp.cache) == cp.countUserDb {
-		users := make([]User, 0, len(cp.cache))
-		for _, user := range cp.cache {
-			users = append(users, user)
-		}
-		return users, nil
-	}
-
-	users, err := cp.repo.GetAll(ctx)
+func (repo *UserRepositoryImpl) FindByEmail(ctx context.Context, email string) (*User, error) {
+	var user User
+	err := repo.db.GetContext(ctx, &user, "SELECT * FROM users WHERE email = ?", email)
 	if err != nil {
 		return nil, err
 	}
-
-	cp.countUserDb = len(users)
-	for _, user := range users {
-		cp.cache[user.ID] = user
-	}
-
-	return users, nil
+	return &user, nil
 }
 
 // Service
 type UserService interface {
 	Create(ctx context.Context, user *User) error
 	GetAll(ctx context.Context) ([]User, error)
+	Login(ctx context.Context, email, password string) (*User, error)
 }
 
 type UserServiceImpl struct {
@@ -134,8 +104,22 @@ type UserServiceImpl struct {
 
 func (u *UserServiceImpl) Create(ctx context.Context, user *User) error {
 	if user.Age < 18 {
-		return fmt.Errorf("Age under 18, registration prohibited")
+		return errs.New(errs.ErrValidationFailed, "age under 18, registration prohibited")
 	}
+
+	salt, err := generateSalt()
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashPassword(user.Password, salt)
+	if err != nil {
+		return err
+	}
+
+	user.Salt = salt
+	user.Password = hash
+
 	return u.repo.Create(ctx, user)
 }
 
@@ -143,6 +127,22 @@ func (u *UserServiceImpl) GetAll(ctx context.Context) ([]User, error) {
 	return u.repo.GetAll(ctx)
 }
 
+func (u *UserServiceImpl) Login(ctx context.Context, email, password string) (*User, error) {
+	user, err := u.repo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.Wrap(err, errs.ErrUnauthenticated, "invalid email or password")
+		}
+		return nil, errs.Wrap(err, errs.ErrInternal, "failed to look up user")
+	}
+
+	if err := verifyPassword(user.Password, user.Salt, password); err != nil {
+		return nil, errs.Wrap(err, errs.ErrUnauthenticated, "invalid email or password")
+	}
+
+	return user, nil
+}
+
 func NewUserServiceImpl(repo UserRepository) *UserServiceImpl {
 	return &UserServiceImpl{repo: repo}
 }
@@ -157,79 +157,61 @@ func NewControllerUser(userService UserService) *ControllerUser {
 }
 
 func (c *ControllerUser) RegisterHandler(w http.ResponseWriter, r *http.Request) {
-	var user User
-	if err := json.NewDecoder(r.Body).Decode(&user); err != nil {
-		http.Error(w, "Invalid request", http.StatusBadRequest)
+	var req RegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errs.Wrap(err, errs.ErrValidationFailed, "invalid request body"))
 		return
 	}
 
+	user := User{
+		Email:    req.Email,
+		Password: req.Password,
+		Name:     req.Name,
+		Age:      req.Age,
+	}
+
 	reqCtx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
 	if err := c.UserService.Create(reqCtx, &user); err != nil {
 		log.Println("ControllerUser.RegisterHandler error: ", err)
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, err)
 		return
 	}
 
-	fmt.Fprint(w, "User successfully registered")
+	w.Write([]byte("User successfully registered"))
 }
 
-func (c *ControllerUser) GetUsersHandler(w http.ResponseWriter, r *http.Request) {
+func (c *ControllerUser) LoginHandler(w http.ResponseWriter, r *http.Request) {
+	var req LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, errs.Wrap(err, errs.ErrValidationFailed, "invalid request body"))
+		return
+	}
+
 	reqCtx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
-	user, err := c.UserService.GetAll(reqCtx)
+	user, err := c.UserService.Login(reqCtx, req.Email, req.Password)
 	if err != nil {
-		log.Println("ControllerUser.GetUsersHandler error: ", err)
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		log.Println("ControllerUser.LoginHandler error: ", err)
+		writeError(w, err)
 		return
 	}
 
 	json.NewEncoder(w).Encode(user)
 }
 
-func main() {
-	db, err := initDB()
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer db.Close()
-
-	ctx := context.Background()
-
-	controller := NewControllerUser(NewUserServiceImpl(NewCacheProxy(NewUserRepositoryImpl(db))))
-
-	r := chi.NewRouter()
-
-	r.Post("/user", controller.RegisterHandler)
-	r.Get("/user", controller.GetUsersHandler)
-
-	server := &http.Server{
-		Addr:         ":8080",
-		Handler:      r,
-		ReadTimeout:  10 * time.Second,
-		WriteTimeout: 10 * time.Second,
-	}
-
-	stop := make(chan os.Signal, 1)
-	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
-
-	go func() {
-		log.Println("Starting server on :8080...")
-		if err = server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			log.Fatalf("Server error: %v", err)
-		}
-	}()
-
-	<-stop
-
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+func (c *ControllerUser) GetUsersHandler(w http.ResponseWriter, r *http.Request) {
+	reqCtx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
 	defer cancel()
 
-	if err = server.Shutdown(ctx); err != nil {
-		log.Fatalf("Server shutdown error: %v", err)
+	user, err := c.UserService.GetAll(reqCtx)
+	if err != nil {
+		log.Println("ControllerUser.GetUsersHandler error: ", err)
+		writeError(w, err)
+		return
 	}
 
-	log.Println("Server stopped gracefully")
+	json.NewEncoder(w).Encode(user)
 }
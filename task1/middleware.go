@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/KirillGreenev/test-tasks-GO/task1/errs"
+)
+
+// errorStatus maps a typed error code to the HTTP status it should be
+// reported as.
+var errorStatus = map[errs.Code]int{
+	errs.ErrValidationFailed: http.StatusBadRequest,
+	errs.ErrAlreadyExists:    http.StatusConflict,
+	errs.ErrNotFound:         http.StatusNotFound,
+	errs.ErrUnauthenticated:  http.StatusUnauthorized,
+	errs.ErrDeadlineExceeded: http.StatusGatewayTimeout,
+	errs.ErrInternal:         http.StatusInternalServerError,
+}
+
+// errorResponse is the JSON body written for any *errs.Error returned from
+// a handler.
+type errorResponse struct {
+	Code    errs.Code `json:"code"`
+	Message string    `json:"message"`
+}
+
+// RecoverMiddleware recovers from panics in the handler chain and reports
+// them through writeError as an ErrInternal, so a panic produces the same
+// JSON error body that handlers write for their own *errs.Error values.
+func RecoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rec := recover(); rec != nil {
+				log.Println("recovered from panic: ", rec)
+				writeError(w, errs.New(errs.ErrInternal, "internal server error"))
+			}
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeError writes err as a JSON error body with the status derived from
+// its *errs.Error code, falling back to 500 for untyped errors.
+func writeError(w http.ResponseWriter, err error) {
+	var typed *errs.Error
+	if !errors.As(err, &typed) {
+		typed = errs.Wrap(err, errs.ErrInternal, "internal server error")
+	}
+
+	status, ok := errorStatus[typed.Code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errorResponse{Code: typed.Code, Message: typed.Message})
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migration is one numbered schema change, with SQL for applying it and for
+// rolling it back.
+type migration struct {
+	version int
+	name    string
+	up      string
+	down    string
+}
+
+// loadMigrations reads migrations/*.sql and pairs up each version's .up.sql
+// and .down.sql into a version-ordered list.
+func loadMigrations() ([]migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, fmt.Errorf("loadMigrations: %w", err)
+	}
+
+	byVersion := make(map[int]*migration)
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		version, rest, direction, err := parseMigrationFilename(name)
+		if err != nil {
+			return nil, fmt.Errorf("loadMigrations: %w", err)
+		}
+
+		content, err := migrationFiles.ReadFile("migrations/" + name)
+		if err != nil {
+			return nil, fmt.Errorf("loadMigrations: %w", err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &migration{version: version, name: rest}
+			byVersion[version] = m
+		}
+
+		if direction == "up" {
+			m.up = string(content)
+		} else {
+			m.down = string(content)
+		}
+	}
+
+	migrations := make([]migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].version < migrations[j].version })
+
+	return migrations, nil
+}
+
+// parseMigrationFilename splits "0002_add_salt.up.sql" into version 2, name
+// "add_salt" and direction "up".
+func parseMigrationFilename(name string) (version int, rest, direction string, err error) {
+	base := strings.TrimSuffix(name, ".sql")
+
+	direction = "up"
+	switch {
+	case strings.HasSuffix(base, ".up"):
+		base = strings.TrimSuffix(base, ".up")
+	case strings.HasSuffix(base, ".down"):
+		base = strings.TrimSuffix(base, ".down")
+		direction = "down"
+	default:
+		return 0, "", "", fmt.Errorf("migration file %q missing .up/.down suffix", name)
+	}
+
+	parts := strings.SplitN(base, "_", 2)
+	if len(parts) != 2 {
+		return 0, "", "", fmt.Errorf("migration file %q missing version prefix", name)
+	}
+
+	version, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", "", fmt.Errorf("migration file %q has invalid version: %w", name, err)
+	}
+
+	return version, parts[1], direction, nil
+}
+
+// Migrator tracks and applies schema migrations against db, recording
+// applied versions in schema_migrations so the same migration never runs
+// twice.
+type Migrator struct {
+	db *sqlx.DB
+}
+
+func NewMigrator(db *sqlx.DB) *Migrator {
+	return &Migrator{db: db}
+}
+
+func (m *Migrator) ensureSchemaMigrationsTable() error {
+	_, err := m.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);`)
+	return err
+}
+
+func (m *Migrator) appliedVersions() (map[int]bool, error) {
+	var versions []int
+	if err := m.db.Select(&versions, "SELECT version FROM schema_migrations"); err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]bool, len(versions))
+	for _, v := range versions {
+		applied[v] = true
+	}
+	return applied, nil
+}
+
+// Up applies every migration that hasn't been recorded in
+// schema_migrations yet, in version order, each inside its own transaction.
+func (m *Migrator) Up() error {
+	if err := m.ensureSchemaMigrationsTable(); err != nil {
+		return fmt.Errorf("Migrator.Up: %w", err)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return fmt.Errorf("Migrator.Up: %w", err)
+	}
+
+	applied, err := m.appliedVersions()
+	if err != nil {
+		return fmt.Errorf("Migrator.Up: %w", err)
+	}
+
+	for _, mig := range migrations {
+		if applied[mig.version] {
+			continue
+		}
+
+		if err := m.apply(mig.version, mig.up); err != nil {
+			return fmt.Errorf("Migrator.Up: migration %d (%s): %w", mig.version, mig.name, err)
+		}
+	}
+
+	return nil
+}
+
+// Down rolls back the most recently applied migration.
+func (m *Migrator) Down() error {
+	if err := m.ensureSchemaMigrationsTable(); err != nil {
+		return fmt.Errorf("Migrator.Down: %w", err)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return fmt.Errorf("Migrator.Down: %w", err)
+	}
+
+	applied, err := m.appliedVersions()
+	if err != nil {
+		return fmt.Errorf("Migrator.Down: %w", err)
+	}
+
+	var last *migration
+	for i := range migrations {
+		if applied[migrations[i].version] {
+			last = &migrations[i]
+		}
+	}
+	if last == nil {
+		return nil
+	}
+
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return fmt.Errorf("Migrator.Down: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(last.down); err != nil {
+		return fmt.Errorf("Migrator.Down: migration %d (%s): %w", last.version, last.name, err)
+	}
+	if _, err := tx.Exec("DELETE FROM schema_migrations WHERE version = ?", last.version); err != nil {
+		return fmt.Errorf("Migrator.Down: migration %d (%s): %w", last.version, last.name, err)
+	}
+
+	return tx.Commit()
+}
+
+func (m *Migrator) apply(version int, sql string) error {
+	tx, err := m.db.Beginx()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(sql); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES (?)", version); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Status reports, for every known migration, whether it has been applied.
+func (m *Migrator) Status() (string, error) {
+	if err := m.ensureSchemaMigrationsTable(); err != nil {
+		return "", fmt.Errorf("Migrator.Status: %w", err)
+	}
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		return "", fmt.Errorf("Migrator.Status: %w", err)
+	}
+
+	applied, err := m.appliedVersions()
+	if err != nil {
+		return "", fmt.Errorf("Migrator.Status: %w", err)
+	}
+
+	var b strings.Builder
+	for _, mig := range migrations {
+		state := "pending"
+		if applied[mig.version] {
+			state = "applied"
+		}
+		fmt.Fprintf(&b, "%04d_%s: %s\n", mig.version, mig.name, state)
+	}
+
+	return b.String(), nil
+}
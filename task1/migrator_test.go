@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func openTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	db, err := sqlx.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("sqlx.Open: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func TestMigratorUpDownUp(t *testing.T) {
+	db := openTestDB(t)
+	m := NewMigrator(db)
+
+	if err := m.Up(); err != nil {
+		t.Fatalf("Up (1st): %v", err)
+	}
+
+	var tableCount int
+	if err := db.Get(&tableCount, "SELECT count(*) FROM sqlite_master WHERE type='table' AND name='users'"); err != nil {
+		t.Fatalf("checking users table exists: %v", err)
+	}
+	if tableCount != 1 {
+		t.Fatalf("expected users table to exist after Up, got count=%d", tableCount)
+	}
+
+	status, err := m.Status()
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if strings.Contains(status, "pending") {
+		t.Fatalf("expected every migration applied after Up, got status:\n%s", status)
+	}
+
+	if err := m.Down(); err != nil {
+		t.Fatalf("Down: %v", err)
+	}
+
+	status, err = m.Status()
+	if err != nil {
+		t.Fatalf("Status after Down: %v", err)
+	}
+	if !strings.Contains(status, "pending") {
+		t.Fatalf("expected the last migration to be pending after Down, got status:\n%s", status)
+	}
+
+	if err := m.Up(); err != nil {
+		t.Fatalf("Up (2nd): %v", err)
+	}
+
+	status, err = m.Status()
+	if err != nil {
+		t.Fatalf("Status after 2nd Up: %v", err)
+	}
+	if strings.Contains(status, "pending") {
+		t.Fatalf("expected every migration applied after the 2nd Up, got status:\n%s", status)
+	}
+}
+
+func TestMigratorDownWithNoMigrationsAppliedIsANoOp(t *testing.T) {
+	db := openTestDB(t)
+	m := NewMigrator(db)
+
+	if err := m.Down(); err != nil {
+		t.Fatalf("Down with nothing applied should be a no-op, got: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// pepper is mixed into every password hash in addition to the per-user
+// salt. App sets it once at startup from Config so the value never lives
+// in source control or the database.
+var pepper string
+
+// SetPepper configures the pepper used by hashPassword/verifyPassword.
+func SetPepper(p string) {
+	pepper = p
+}
+
+// generateSalt returns a random, base64-encoded 16 byte salt suitable for
+// storing alongside a user's password hash.
+func generateSalt() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generateSalt: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(buf), nil
+}
+
+// hashPassword combines the configured pepper with the per-user salt and
+// returns a base64-encoded bcrypt hash ready for storage.
+func hashPassword(password, salt string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(pepper+salt+password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("hashPassword: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(hash), nil
+}
+
+// verifyPassword reports whether password, combined with salt and the
+// configured pepper, produces the given base64-encoded bcrypt hash.
+func verifyPassword(hash, salt, password string) error {
+	decoded, err := base64.StdEncoding.DecodeString(hash)
+	if err != nil {
+		return fmt.Errorf("verifyPassword: %w", err)
+	}
+	return bcrypt.CompareHashAndPassword(decoded, []byte(pepper+salt+password))
+}
@@ -0,0 +1,62 @@
+package main
+
+import "testing"
+
+func TestHashAndVerifyPasswordRoundTrip(t *testing.T) {
+	SetPepper("test-pepper")
+
+	salt, err := generateSalt()
+	if err != nil {
+		t.Fatalf("generateSalt: %v", err)
+	}
+
+	hash, err := hashPassword("correct horse battery staple", salt)
+	if err != nil {
+		t.Fatalf("hashPassword: %v", err)
+	}
+
+	if err := verifyPassword(hash, salt, "correct horse battery staple"); err != nil {
+		t.Fatalf("verifyPassword: got error for the correct password: %v", err)
+	}
+
+	if err := verifyPassword(hash, salt, "wrong password"); err == nil {
+		t.Fatal("verifyPassword: expected an error for the wrong password, got nil")
+	}
+}
+
+func TestVerifyPasswordWrongSalt(t *testing.T) {
+	SetPepper("test-pepper")
+
+	salt, err := generateSalt()
+	if err != nil {
+		t.Fatalf("generateSalt: %v", err)
+	}
+
+	hash, err := hashPassword("correct horse battery staple", salt)
+	if err != nil {
+		t.Fatalf("hashPassword: %v", err)
+	}
+
+	otherSalt, err := generateSalt()
+	if err != nil {
+		t.Fatalf("generateSalt: %v", err)
+	}
+
+	if err := verifyPassword(hash, otherSalt, "correct horse battery staple"); err == nil {
+		t.Fatal("verifyPassword: expected an error for a mismatched salt, got nil")
+	}
+}
+
+func TestGenerateSaltIsRandom(t *testing.T) {
+	a, err := generateSalt()
+	if err != nil {
+		t.Fatalf("generateSalt: %v", err)
+	}
+	b, err := generateSalt()
+	if err != nil {
+		t.Fatalf("generateSalt: %v", err)
+	}
+	if a == b {
+		t.Fatal("generateSalt: two calls returned the same salt")
+	}
+}